@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BackendConfig is one named backend entry in a backends manifest: a type
+// (which implementation to construct) plus whatever params that
+// implementation needs.
+type BackendConfig struct {
+	Name   string            `yaml:"name"`
+	Type   string            `yaml:"type"`
+	Params map[string]string `yaml:"params"`
+}
+
+// ActiveBackends names which configured backend to use for each role.
+type ActiveBackends struct {
+	Embedder    string `yaml:"embedder"`
+	VectorStore string `yaml:"vector_store"`
+	Generator   string `yaml:"generator"`
+}
+
+// BackendsManifest is the config-driven replacement for a fixed set of env
+// vars: callers build services by looking up named backends instead of
+// hard-coding which implementation to construct.
+type BackendsManifest struct {
+	Backends []BackendConfig `yaml:"backends"`
+	Active   ActiveBackends  `yaml:"active"`
+}
+
+// Backend looks up a named entry, returning an error if it isn't declared.
+func (m *BackendsManifest) Backend(name string) (BackendConfig, error) {
+	for _, b := range m.Backends {
+		if b.Name == name {
+			return b, nil
+		}
+	}
+	return BackendConfig{}, fmt.Errorf("backend %q not found in manifest", name)
+}
+
+func loadBackendsManifest(path string) (*BackendsManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest BackendsManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse backends manifest %s: %w", path, err)
+	}
+
+	return &manifest, nil
+}
+
+// defaultBackendsManifest mirrors the module's historical fixed env vars, so
+// deployments without a backends manifest file keep working unchanged.
+func defaultBackendsManifest(c *Config) *BackendsManifest {
+	return &BackendsManifest{
+		Backends: []BackendConfig{
+			{
+				Name: "tei",
+				Type: "tei",
+				Params: map[string]string{
+					"base_url": c.TEIBaseURL,
+				},
+			},
+			{
+				Name: "weaviate",
+				Type: "weaviate",
+				Params: map[string]string{
+					"host":       c.WeaviateHost,
+					"scheme":     c.WeaviateScheme,
+					"collection": c.CollectionName,
+				},
+			},
+			{
+				Name: "vllm",
+				Type: "vllm",
+				Params: map[string]string{
+					"base_url": c.VLLMBaseURL,
+					"model":    c.VLLMModelName,
+				},
+			},
+		},
+		Active: ActiveBackends{
+			Embedder:    "tei",
+			VectorStore: "weaviate",
+			Generator:   "vllm",
+		},
+	}
+}
@@ -1,22 +1,38 @@
 package config
 
 import (
+	"log"
 	"os"
+	"strconv"
 	"time"
 )
 
 const (
-	DefaultVLLMBaseURL    = "http://localhost:8000/v1"
-	DefaultTEIBaseURL     = "http://localhost:8082"
-	DefaultVLLMModelName  = "microsoft/Phi-3-mini-128k-instruct"
-	DefaultWeaviateHost   = "localhost:8080"
-	DefaultWeaviateScheme = "http"
-	DefaultCollectionName = "LlamaIndex"
-	DefaultServerPort     = ":8081"
-	DefaultMaxTokens      = 4096
-	DefaultSearchLimit    = 3
-	DefaultHTTPTimeout    = 30 * time.Second
-	ShutdownTimeout       = 10 * time.Second
+	DefaultVLLMBaseURL      = "http://localhost:8000/v1"
+	DefaultTEIBaseURL       = "http://localhost:8082"
+	DefaultVLLMModelName    = "microsoft/Phi-3-mini-128k-instruct"
+	DefaultWeaviateHost     = "localhost:8080"
+	DefaultWeaviateScheme   = "http"
+	DefaultCollectionName   = "LlamaIndex"
+	DefaultServerPort       = ":8081"
+	DefaultMaxTokens        = 4096
+	DefaultSearchLimit      = 3
+	DefaultHTTPTimeout      = 30 * time.Second
+	ShutdownTimeout         = 10 * time.Second
+	DefaultChunkSize        = 256
+	DefaultChunkOverlap     = 32
+	DefaultEmbedBatchSize   = 32
+	DefaultBackendsManifest = "backends.yaml"
+	DefaultRetrievalAlpha   = 0.5
+	DefaultRRFK             = 60
+	DefaultRerankTopN       = 0
+	DefaultFinalK           = 3
+
+	DefaultEmbedStageTimeout      = 10 * time.Second
+	DefaultRetrievalStageTimeout  = 10 * time.Second
+	DefaultGenerationStageTimeout = 30 * time.Second
+	DefaultTEIMaxInflight         = 16
+	DefaultVLLMMaxInflight        = 8
 )
 
 type Config struct {
@@ -29,10 +45,24 @@ type Config struct {
 	ServerPort     string
 	MaxTokens      int
 	SearchLimit    int
+	ChunkSize      int
+	ChunkOverlap   int
+	EmbedBatchSize int
+	Backends       *BackendsManifest
+	RetrievalAlpha float64
+	RRFK           int
+	RerankTopN     int
+	FinalK         int
+
+	EmbedStageTimeout      time.Duration
+	RetrievalStageTimeout  time.Duration
+	GenerationStageTimeout time.Duration
+	TEIMaxInflight         int
+	VLLMMaxInflight        int
 }
 
 func Load() *Config {
-	return &Config{
+	cfg := &Config{
 		VLLMBaseURL:    getEnv("VLLM_BASE_URL", DefaultVLLMBaseURL),
 		TEIBaseURL:     getEnv("TEI_BASE_URL", DefaultTEIBaseURL),
 		VLLMModelName:  getEnv("VLLM_MODEL_NAME", DefaultVLLMModelName),
@@ -42,7 +72,32 @@ func Load() *Config {
 		ServerPort:     getEnv("SERVER_PORT", DefaultServerPort),
 		MaxTokens:      DefaultMaxTokens,
 		SearchLimit:    DefaultSearchLimit,
+		ChunkSize:      getEnvInt("INGEST_CHUNK_SIZE", DefaultChunkSize),
+		ChunkOverlap:   getEnvInt("INGEST_CHUNK_OVERLAP", DefaultChunkOverlap),
+		EmbedBatchSize: getEnvInt("INGEST_EMBED_BATCH_SIZE", DefaultEmbedBatchSize),
+		RetrievalAlpha: getEnvFloat("RETRIEVAL_ALPHA", DefaultRetrievalAlpha),
+		RRFK:           getEnvInt("RETRIEVAL_RRF_K", DefaultRRFK),
+		RerankTopN:     getEnvInt("RETRIEVAL_RERANK_TOP_N", DefaultRerankTopN),
+		FinalK:         getEnvInt("RETRIEVAL_FINAL_K", DefaultFinalK),
+
+		EmbedStageTimeout:      getEnvDuration("EMBED_STAGE_TIMEOUT", DefaultEmbedStageTimeout),
+		RetrievalStageTimeout:  getEnvDuration("RETRIEVAL_STAGE_TIMEOUT", DefaultRetrievalStageTimeout),
+		GenerationStageTimeout: getEnvDuration("GENERATION_STAGE_TIMEOUT", DefaultGenerationStageTimeout),
+		TEIMaxInflight:         getEnvInt("TEI_MAX_INFLIGHT", DefaultTEIMaxInflight),
+		VLLMMaxInflight:        getEnvInt("VLLM_MAX_INFLIGHT", DefaultVLLMMaxInflight),
 	}
+
+	manifestPath := getEnv("BACKENDS_CONFIG_FILE", DefaultBackendsManifest)
+	if manifest, err := loadBackendsManifest(manifestPath); err == nil {
+		cfg.Backends = manifest
+	} else {
+		if !os.IsNotExist(err) {
+			log.Printf("backends manifest %s is invalid, falling back to defaults: %v", manifestPath, err)
+		}
+		cfg.Backends = defaultBackendsManifest(cfg)
+	}
+
+	return cfg
 }
 
 func getEnv(key, defaultValue string) string {
@@ -51,3 +106,42 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"rag-api/models"
+	"rag-api/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type IngestHandler struct {
+	ingestionService *services.IngestionService
+	uploads          *uploadStore
+}
+
+func NewIngestHandler(ingestionService *services.IngestionService) *IngestHandler {
+	return &IngestHandler{
+		ingestionService: ingestionService,
+		uploads:          newUploadStore(),
+	}
+}
+
+// Ingest accepts a single multipart file upload, small enough to embed and
+// index in one request.
+func (h *IngestHandler) Ingest(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "file is required")
+	}
+
+	source := c.FormValue("source", fileHeader.Filename)
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	count, err := h.ingestionService.IngestDocument(c.Context(), source, fileHeader.Header.Get("Content-Type"), data)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(models.IngestResponse{Source: source, ChunksIndexed: count})
+}
+
+// CreateUpload starts a resumable upload session for a larger document and
+// returns its location.
+func (h *IngestHandler) CreateUpload(c *fiber.Ctx) error {
+	var req struct {
+		Source      string `json:"source"`
+		ContentType string `json:"content_type"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	if req.Source == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "source is required")
+	}
+
+	id, err := h.uploads.create(req.Source, req.ContentType)
+	if err != nil {
+		return fmt.Errorf("failed to create upload session: %w", err)
+	}
+
+	location := "/ingest/uploads/" + id
+	c.Set("Location", location)
+	c.Set("Range", "bytes=0-0")
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"upload_id": id, "location": location})
+}
+
+// PatchUpload appends one chunk to an in-progress upload. The chunk must
+// start at the offset given by the X-Upload-Offset header, matching the
+// number of bytes already committed.
+func (h *IngestHandler) PatchUpload(c *fiber.Ctx) error {
+	id := c.Params("uuid")
+	session, ok := h.uploads.get(id)
+	if !ok {
+		return fiber.NewError(fiber.StatusNotFound, "unknown upload")
+	}
+
+	offset, err := strconv.ParseInt(c.Get("X-Upload-Offset"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "X-Upload-Offset header is required")
+	}
+
+	newOffset, err := session.appendAt(offset, c.Body())
+	if err != nil {
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+
+	location := "/ingest/uploads/" + id
+	c.Set("Location", location)
+	c.Set("Range", fmt.Sprintf("bytes=0-%d", newOffset-1))
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// FinalizeUpload closes an upload session, embeds and indexes the assembled
+// document, then discards the session.
+func (h *IngestHandler) FinalizeUpload(c *fiber.Ctx) error {
+	id := c.Params("uuid")
+	session, ok := h.uploads.get(id)
+	if !ok {
+		return fiber.NewError(fiber.StatusNotFound, "unknown upload")
+	}
+	defer h.uploads.delete(id)
+
+	count, err := h.ingestionService.IngestDocument(c.Context(), session.source, session.contentType, session.bytes())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(models.IngestResponse{Source: session.source, ChunksIndexed: count})
+}
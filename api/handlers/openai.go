@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"rag-api/models"
+	"rag-api/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sashabaranov/go-openai"
+)
+
+// ragModelPrefix lets OpenAI SDK clients opt into retrieval-augmented
+// generation by model id (e.g. "rag-phi-3") instead of setting the rag flag.
+const ragModelPrefix = "rag-"
+
+type OpenAIHandler struct {
+	ragService  *services.RAGService
+	vllmService *services.VLLMService
+	teiService  *services.TEIService
+	modelName   string
+}
+
+func NewOpenAIHandler(ragService *services.RAGService, vllmService *services.VLLMService, teiService *services.TEIService, modelName string) *OpenAIHandler {
+	return &OpenAIHandler{
+		ragService:  ragService,
+		vllmService: vllmService,
+		teiService:  teiService,
+		modelName:   modelName,
+	}
+}
+
+func (h *OpenAIHandler) ChatCompletions(c *fiber.Ctx) error {
+	var req models.ChatCompletionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if len(req.Messages) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "messages cannot be empty")
+	}
+
+	useRAG := req.RAG || strings.HasPrefix(req.Model, ragModelPrefix)
+	if useRAG {
+		query := lastUserMessage(req.Messages)
+		if query == "" {
+			return fiber.NewError(fiber.StatusBadRequest, "a user message is required for rag")
+		}
+
+		contextStr, err := h.ragService.RetrieveContext(c.Context(), query)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve context: %w", err)
+		}
+
+		req.Messages = append([]openai.ChatCompletionMessage{{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: ragSystemPrompt(contextStr),
+		}}, req.Messages...)
+	}
+
+	req.Model = strings.TrimPrefix(req.Model, ragModelPrefix)
+
+	if req.Stream {
+		return h.streamChatCompletion(c, req.ChatCompletionRequest)
+	}
+
+	resp, err := h.vllmService.CreateChatCompletion(c.Context(), req.ChatCompletionRequest)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *OpenAIHandler) streamChatCompletion(c *fiber.Ctx, req openai.ChatCompletionRequest) error {
+	return streamSSE(c, func(ctx context.Context) (func(context.Context, *bufio.Writer) error, error) {
+		tokens, err := h.vllmService.StreamChatCompletion(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(ctx context.Context, w *bufio.Writer) error {
+			for {
+				select {
+				case token, ok := <-tokens:
+					if !ok {
+						return writeSSEData(w, "[DONE]")
+					}
+					if token.Err != nil {
+						return token.Err
+					}
+
+					payload, _ := json.Marshal(fiber.Map{
+						"object": "chat.completion.chunk",
+						"model":  req.Model,
+						"choices": []fiber.Map{{
+							"index": 0,
+							"delta": fiber.Map{"content": token.Content},
+						}},
+					})
+					if err := writeSSEData(w, string(payload)); err != nil {
+						return err
+					}
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}, nil
+	})
+}
+
+func (h *OpenAIHandler) Completions(c *fiber.Ctx) error {
+	var req openai.CompletionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	resp, err := h.vllmService.CreateCompletion(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *OpenAIHandler) Embeddings(c *fiber.Ctx) error {
+	var req models.EmbeddingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	inputs, err := normalizeEmbeddingInput(req.Input)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	embeddings, err := h.teiService.GetEmbeddings(c.Context(), inputs)
+	if err != nil {
+		return fmt.Errorf("failed to get embeddings: %w", err)
+	}
+
+	data := make([]models.EmbeddingData, len(embeddings))
+	for i, embedding := range embeddings {
+		data[i] = models.EmbeddingData{
+			Object:    "embedding",
+			Embedding: embedding,
+			Index:     i,
+		}
+	}
+
+	return c.JSON(models.EmbeddingsResponse{
+		Object: "list",
+		Data:   data,
+		Model:  req.Model,
+	})
+}
+
+func (h *OpenAIHandler) Models(c *fiber.Ctx) error {
+	return c.JSON(models.ModelsResponse{
+		Object: "list",
+		Data: []models.Model{
+			{ID: h.modelName, Object: "model", OwnedBy: "vllm"},
+			{ID: ragModelPrefix + h.modelName, Object: "model", OwnedBy: "rag-api"},
+		},
+	})
+}
+
+func lastUserMessage(messages []openai.ChatCompletionMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == openai.ChatMessageRoleUser {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+func ragSystemPrompt(contextStr string) string {
+	return fmt.Sprintf("Use the following pieces of context to answer the question. "+
+		"If you don't know the answer, just say that you don't know, don't try to make up an answer.\nContext:\n%s", contextStr)
+}
+
+func normalizeEmbeddingInput(input interface{}) ([]string, error) {
+	switch v := input.(type) {
+	case string:
+		if v == "" {
+			return nil, errors.New("input cannot be empty")
+		}
+		return []string{v}, nil
+	case []interface{}:
+		inputs := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.New("input must be a string or an array of strings")
+			}
+			inputs = append(inputs, s)
+		}
+		if len(inputs) == 0 {
+			return nil, errors.New("input cannot be empty")
+		}
+		return inputs, nil
+	default:
+		return nil, errors.New("input must be a string or an array of strings")
+	}
+}
@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+
 	"rag-api/models"
 	"rag-api/services"
 
@@ -29,10 +33,69 @@ func (h *QueryHandler) Handle(c *fiber.Ctx) error {
 
 	ctx := c.Context()
 
-	answer, err := h.ragService.Query(ctx, req.Query)
+	answer, err := h.ragService.Query(ctx, req.Query, retrieveOptionsFromRequest(req))
 	if err != nil {
 		return err
 	}
 
 	return c.JSON(models.QueryResponse{Response: answer})
 }
+
+// HandleStream is the SSE counterpart to Handle: it emits a "sources" event
+// with the retrieved chunks followed by one "token" event per generated
+// token delta.
+func (h *QueryHandler) HandleStream(c *fiber.Ctx) error {
+	var req models.QueryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if req.Query == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Query cannot be empty")
+	}
+
+	return streamSSE(c, func(ctx context.Context) (func(context.Context, *bufio.Writer) error, error) {
+		events, err := h.ragService.QueryStream(ctx, req.Query, retrieveOptionsFromRequest(req))
+		if err != nil {
+			return nil, err
+		}
+
+		return func(ctx context.Context, w *bufio.Writer) error {
+			for {
+				select {
+				case event, ok := <-events:
+					if !ok {
+						return nil
+					}
+					if event.Err != nil {
+						return event.Err
+					}
+
+					switch event.Type {
+					case services.StreamEventSources:
+						payload, _ := json.Marshal(fiber.Map{"sources": event.Sources})
+						if err := writeSSEEvent(w, string(services.StreamEventSources), string(payload)); err != nil {
+							return err
+						}
+					case services.StreamEventToken:
+						payload, _ := json.Marshal(fiber.Map{"token": event.Token})
+						if err := writeSSEEvent(w, string(services.StreamEventToken), string(payload)); err != nil {
+							return err
+						}
+					}
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}, nil
+	})
+}
+
+func retrieveOptionsFromRequest(req models.QueryRequest) services.RetrieveOptions {
+	return services.RetrieveOptions{
+		Alpha:      req.Alpha,
+		K:          req.K,
+		RerankTopN: req.RerankTopN,
+		FinalK:     req.FinalK,
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// sseHeartbeatInterval bounds how long a proxy or client will sit without
+// seeing a byte before deciding the connection is dead.
+const sseHeartbeatInterval = 15 * time.Second
+
+// streamSSE sets the response headers for an SSE stream, calls start to kick
+// off the upstream work, then runs the produce func it returns in the
+// background, interleaving keepalive comment frames so idle connections
+// aren't dropped by intermediaries.
+//
+// fasthttp's RequestCtx.Done() only ever fires on full server shutdown, not
+// on an individual client disconnecting mid-stream, so c.Context() alone
+// can't be handed to start as a disconnect-aware context. Instead start (and
+// produce) are given a context derived via context.WithCancel, which this
+// function cancels itself as soon as the write loop below exits for any
+// reason — including a failed write, which is how a client disconnect
+// actually surfaces here. That promptly stops whatever start kicked off
+// upstream instead of leaving it running until its own stage deadline.
+func streamSSE(c *fiber.Ctx, start func(ctx context.Context) (produce func(ctx context.Context, w *bufio.Writer) error, err error)) error {
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	ctx, cancel := context.WithCancel(c.Context())
+
+	produce, err := start(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- produce(ctx, w)
+		}()
+
+		ticker := time.NewTicker(sseHeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case err := <-done:
+				if err != nil {
+					_ = writeSSEEvent(w, "error", fmt.Sprintf(`{"error":%q}`, err.Error()))
+				}
+				return
+			case <-ticker.C:
+				if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}))
+
+	return nil
+}
+
+func writeSSEData(w *bufio.Writer, data string) error {
+	if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func writeSSEEvent(w *bufio.Writer, event, data string) error {
+	if _, err := fmt.Fprintf(w, "event: %s\n", event); err != nil {
+		return err
+	}
+	return writeSSEData(w, data)
+}
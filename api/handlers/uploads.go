@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"sync"
+)
+
+// uploadSession buffers one resumable upload's bytes in memory until it is
+// finalized.
+type uploadSession struct {
+	mu          sync.Mutex
+	source      string
+	contentType string
+	buf         bytes.Buffer
+}
+
+func (u *uploadSession) appendAt(offset int64, data []byte) (int64, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	current := int64(u.buf.Len())
+	if offset != current {
+		return current, fmt.Errorf("offset mismatch: expected %d, got %d", current, offset)
+	}
+
+	u.buf.Write(data)
+	return int64(u.buf.Len()), nil
+}
+
+func (u *uploadSession) bytes() []byte {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]byte(nil), u.buf.Bytes()...)
+}
+
+// uploadStore tracks in-progress resumable uploads by UUID. Sessions are
+// held in memory only and are lost on restart.
+type uploadStore struct {
+	mu       sync.Mutex
+	sessions map[string]*uploadSession
+}
+
+func newUploadStore() *uploadStore {
+	return &uploadStore{sessions: make(map[string]*uploadSession)}
+}
+
+func (s *uploadStore) create(source, contentType string) (string, error) {
+	id, err := newUploadID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate upload id: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[id] = &uploadSession{source: source, contentType: contentType}
+
+	return id, nil
+}
+
+func (s *uploadStore) get(id string) (*uploadSession, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	return session, ok
+}
+
+func (s *uploadStore) delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+}
+
+func newUploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -1,7 +1,15 @@
 package models
 
+import "github.com/sashabaranov/go-openai"
+
+// QueryRequest's retrieval knobs mirror services.RetrieveOptions; zero values
+// mean "use the server's configured defaults".
 type QueryRequest struct {
-	Query string `json:"query" validate:"required,min=1"`
+	Query      string  `json:"query" validate:"required,min=1"`
+	Alpha      float64 `json:"alpha,omitempty"`
+	K          int     `json:"k,omitempty"`
+	RerankTopN int     `json:"rerank_top_n,omitempty"`
+	FinalK     int     `json:"final_k,omitempty"`
 }
 
 type QueryResponse struct {
@@ -19,3 +27,45 @@ type TEIRequest struct {
 }
 
 type TEIResponse [][]float64
+
+// ChatCompletionRequest extends the standard OpenAI chat request with an
+// opt-in flag for routing the request through RAGService before it reaches
+// VLLMService.
+type ChatCompletionRequest struct {
+	openai.ChatCompletionRequest
+	RAG bool `json:"rag,omitempty"`
+}
+
+type EmbeddingsRequest struct {
+	Input interface{} `json:"input"`
+	Model string      `json:"model,omitempty"`
+}
+
+type EmbeddingData struct {
+	Object    string    `json:"object"`
+	Embedding []float64 `json:"embedding"`
+	Index     int       `json:"index"`
+}
+
+type EmbeddingsResponse struct {
+	Object string          `json:"object"`
+	Data   []EmbeddingData `json:"data"`
+	Model  string          `json:"model"`
+}
+
+type Model struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+type ModelsResponse struct {
+	Object string  `json:"object"`
+	Data   []Model `json:"data"`
+}
+
+type IngestResponse struct {
+	Source        string `json:"source"`
+	ChunksIndexed int    `json:"chunks_indexed"`
+}
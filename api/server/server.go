@@ -14,11 +14,12 @@ import (
 	"rag-api/models"
 	"rag-api/services"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sashabaranov/go-openai"
-	"github.com/weaviate/weaviate-go-client/v4/weaviate"
 )
 
 type Server struct {
@@ -36,24 +37,39 @@ func New(cfg *config.Config) (*Server, error) {
 		Timeout: config.DefaultHTTPTimeout,
 	}
 
-	weaviateCfg := weaviate.Config{
-		Host:   cfg.WeaviateHost,
-		Scheme: cfg.WeaviateScheme,
-	}
-	weaviateClient, err := weaviate.NewClient(weaviateCfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Weaviate client: %w", err)
-	}
-
 	log.Printf("Initialized clients - vLLM: %s, TEI: %s, Weaviate: %s",
 		cfg.VLLMBaseURL, cfg.TEIBaseURL, cfg.WeaviateHost)
 
-	teiService := services.NewTEIService(cfg.TEIBaseURL, httpClient)
-	weaviateService := services.NewWeaviateService(weaviateClient, cfg.CollectionName, cfg.SearchLimit)
-	vllmService := services.NewVLLMService(openaiClient, cfg.VLLMModelName, cfg.MaxTokens)
-	ragService := services.NewRAGService(teiService, weaviateService, vllmService)
+	// teiService/vllmService back the OpenAI-compatible surface, which
+	// always speaks the vLLM/TEI protocols directly. RAGService and
+	// IngestionService are built separately below from the backends
+	// manifest, so they can point at different implementations.
+	teiService := services.NewTEIService(cfg.TEIBaseURL, httpClient, cfg.TEIMaxInflight)
+	vllmService := services.NewVLLMService(openaiClient, cfg.VLLMModelName, cfg.MaxTokens, cfg.VLLMMaxInflight)
+
+	embedder, vectorStore, generator, err := buildBackends(cfg, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultRetrieveOpts := services.RetrieveOptions{
+		Alpha:      cfg.RetrievalAlpha,
+		K:          cfg.RRFK,
+		RerankTopN: cfg.RerankTopN,
+		FinalK:     cfg.FinalK,
+	}
+	stageTimeouts := services.StageTimeouts{
+		Embedding:  cfg.EmbedStageTimeout,
+		Retrieval:  cfg.RetrievalStageTimeout,
+		Generation: cfg.GenerationStageTimeout,
+	}
+	// teiService (built above for the OpenAI-compatible embeddings endpoint)
+	// doubles as the cross-encoder reranker regardless of which Embedder
+	// backend is active, since reranking is a TEI-specific capability.
+	ragService := services.NewRAGService(embedder, vectorStore, generator, teiService, defaultRetrieveOpts, stageTimeouts)
+	ingestionService := services.NewIngestionService(embedder, vectorStore, cfg.ChunkSize, cfg.ChunkOverlap, cfg.EmbedBatchSize)
 
-	app := setupApp(ragService)
+	app := setupApp(ragService, vllmService, teiService, ingestionService, cfg.VLLMModelName)
 
 	return &Server{
 		app:        app,
@@ -62,7 +78,40 @@ func New(cfg *config.Config) (*Server, error) {
 	}, nil
 }
 
-func setupApp(ragService *services.RAGService) *fiber.App {
+// buildBackends resolves the active Embedder, VectorStore, and Generator
+// named in cfg.Backends into constructed instances.
+func buildBackends(cfg *config.Config, httpClient *http.Client) (services.Embedder, services.VectorStore, services.Generator, error) {
+	embedderCfg, err := cfg.Backends.Backend(cfg.Backends.Active.Embedder)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to resolve embedder backend: %w", err)
+	}
+	embedder, err := services.BuildEmbedder(embedderCfg, httpClient, cfg.TEIMaxInflight)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to build embedder backend: %w", err)
+	}
+
+	vectorStoreCfg, err := cfg.Backends.Backend(cfg.Backends.Active.VectorStore)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to resolve vector store backend: %w", err)
+	}
+	vectorStore, err := services.BuildVectorStore(vectorStoreCfg, cfg.SearchLimit, httpClient)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to build vector store backend: %w", err)
+	}
+
+	generatorCfg, err := cfg.Backends.Backend(cfg.Backends.Active.Generator)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to resolve generator backend: %w", err)
+	}
+	generator, err := services.BuildGenerator(generatorCfg, cfg.MaxTokens, httpClient, cfg.VLLMMaxInflight)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to build generator backend: %w", err)
+	}
+
+	return embedder, vectorStore, generator, nil
+}
+
+func setupApp(ragService *services.RAGService, vllmService *services.VLLMService, teiService *services.TEIService, ingestionService *services.IngestionService, modelName string) *fiber.App {
 	app := fiber.New(fiber.Config{
 		ErrorHandler: customErrorHandler,
 		AppName:      "RAG API Server",
@@ -77,7 +126,21 @@ func setupApp(ragService *services.RAGService) *fiber.App {
 
 	queryHandler := handlers.NewQueryHandler(ragService)
 	app.Post("/query", queryHandler.Handle)
+	app.Post("/query/stream", queryHandler.HandleStream)
 	app.Get("/health", handlers.HandleHealth)
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+
+	openaiHandler := handlers.NewOpenAIHandler(ragService, vllmService, teiService, modelName)
+	app.Post("/v1/chat/completions", openaiHandler.ChatCompletions)
+	app.Post("/v1/completions", openaiHandler.Completions)
+	app.Post("/v1/embeddings", openaiHandler.Embeddings)
+	app.Get("/v1/models", openaiHandler.Models)
+
+	ingestHandler := handlers.NewIngestHandler(ingestionService)
+	app.Post("/ingest", ingestHandler.Ingest)
+	app.Post("/ingest/uploads", ingestHandler.CreateUpload)
+	app.Patch("/ingest/uploads/:uuid", ingestHandler.PatchUpload)
+	app.Put("/ingest/uploads/:uuid", ingestHandler.FinalizeUpload)
 
 	return app
 }
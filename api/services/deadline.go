@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Stage identifies which phase of RAGService.Query a StageTimeoutError
+// occurred in.
+type Stage string
+
+const (
+	StageEmbedding  Stage = "embedding"
+	StageRetrieval  Stage = "retrieval"
+	StageGeneration Stage = "generation"
+)
+
+// StageTimeoutError reports that a RAGService pipeline stage didn't finish
+// within its configured soft deadline. The in-flight downstream call for
+// that stage is canceled via its derived context; earlier stages' results
+// (e.g. already-retrieved chunks) are discarded along with it.
+type StageTimeoutError struct {
+	Stage   Stage
+	Timeout time.Duration
+}
+
+func (e *StageTimeoutError) Error() string {
+	return fmt.Sprintf("%s stage exceeded its %s soft deadline", e.Stage, e.Timeout)
+}
+
+// StageTimeouts configures the soft per-stage deadlines RAGService.Query
+// enforces. A zero duration disables the deadline for that stage.
+type StageTimeouts struct {
+	Embedding  time.Duration
+	Retrieval  time.Duration
+	Generation time.Duration
+}
+
+// withStageDeadline runs fn under a context bounded by timeout, canceling
+// fn's in-flight call when the deadline passes and translating that into a
+// StageTimeoutError naming stage. A non-positive timeout disables the
+// deadline and runs fn under ctx unchanged.
+func withStageDeadline(ctx context.Context, stage Stage, timeout time.Duration, fn func(context.Context) error) error {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	stageCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := fn(stageCtx)
+	if err != nil && stageCtx.Err() == context.DeadlineExceeded {
+		recordStageTimeout(stage)
+		return &StageTimeoutError{Stage: stage, Timeout: timeout}
+	}
+	return err
+}
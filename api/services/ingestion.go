@@ -0,0 +1,146 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+type IngestionService struct {
+	embedder       Embedder
+	vectorStore    VectorStore
+	chunkSize      int
+	chunkOverlap   int
+	embedBatchSize int
+}
+
+func NewIngestionService(embedder Embedder, vectorStore VectorStore, chunkSize, chunkOverlap, embedBatchSize int) *IngestionService {
+	return &IngestionService{
+		embedder:       embedder,
+		vectorStore:    vectorStore,
+		chunkSize:      chunkSize,
+		chunkOverlap:   chunkOverlap,
+		embedBatchSize: embedBatchSize,
+	}
+}
+
+// IngestDocument extracts text from a raw upload (PDF, Markdown, or plain
+// text, selected by contentType) and ingests it.
+func (s *IngestionService) IngestDocument(ctx context.Context, source, contentType string, data []byte) (int, error) {
+	text, err := extractText(contentType, data)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Ingest(ctx, source, text)
+}
+
+// Ingest splits text into chunks, embeds them in batches, and writes them to
+// the vector store tagged with source. It returns the number of chunks
+// indexed.
+func (s *IngestionService) Ingest(ctx context.Context, source, text string) (int, error) {
+	chunks := splitIntoChunks(text, s.chunkSize, s.chunkOverlap)
+	if len(chunks) == 0 {
+		return 0, errors.New("document produced no chunks")
+	}
+
+	embeddings, err := s.embedder.GetEmbeddingsBatch(ctx, chunks, s.embedBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to embed chunks: %w", err)
+	}
+
+	indexed := make([]Chunk, len(chunks))
+	for i, chunkText := range chunks {
+		indexed[i] = Chunk{
+			Text:      chunkText,
+			Source:    source,
+			Index:     i,
+			Hash:      chunkHash(chunkText),
+			Embedding: embeddings[i],
+		}
+	}
+
+	if err := s.vectorStore.IndexChunks(ctx, indexed); err != nil {
+		return 0, err
+	}
+
+	return len(indexed), nil
+}
+
+// splitIntoChunks is a word-count-based approximation of a token-aware
+// splitter: it treats whitespace-delimited words as a proxy for tokens since
+// the module has no model-specific tokenizer available. size and overlap are
+// both measured in words.
+func splitIntoChunks(text string, size, overlap int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	if size <= 0 {
+		size = len(words)
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	step := size - overlap
+	var chunks []string
+	for start := 0; start < len(words); start += step {
+		end := start + size
+		if end > len(words) {
+			end = len(words)
+		}
+
+		chunks = append(chunks, strings.Join(words[start:end], " "))
+		if end == len(words) {
+			break
+		}
+	}
+
+	return chunks
+}
+
+func chunkHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// extractText converts raw upload bytes to plain text based on the source's
+// content type. Markdown and plain text pass through unchanged; PDFs are
+// text-extracted page by page.
+func extractText(contentType string, data []byte) (string, error) {
+	if strings.Contains(contentType, "pdf") {
+		return extractPDFText(data)
+	}
+	return string(data), nil
+}
+
+func extractPDFText(data []byte) (string, error) {
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open PDF: %w", err)
+	}
+
+	var builder strings.Builder
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+
+		text, err := page.GetPlainText(nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to extract text from PDF page %d: %w", i, err)
+		}
+		builder.WriteString(text)
+		builder.WriteString("\n")
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,45 @@
+package services
+
+import "context"
+
+// Embedder turns text into vectors. TEIService is the default
+// implementation; OpenAIEmbedder is a drop-in alternative selected via the
+// backends manifest.
+type Embedder interface {
+	GetEmbedding(ctx context.Context, text string) ([]float64, error)
+	GetEmbeddings(ctx context.Context, texts []string) ([][]float64, error)
+	GetEmbeddingsBatch(ctx context.Context, texts []string, batchSize int) ([][]float64, error)
+}
+
+// VectorStore retrieves and persists embedded chunks. WeaviateService is the
+// default implementation; QdrantVectorStore is a drop-in alternative
+// selected via the backends manifest.
+type VectorStore interface {
+	Retrieve(ctx context.Context, query string, embedding []float64, opts RetrieveOptions) ([]string, error)
+	IndexChunks(ctx context.Context, chunks []Chunk) error
+}
+
+// Reranker reorders a set of retrieved texts by relevance to a query,
+// returning indices into texts ordered best-first. TEIService is the only
+// implementation, via its cross-encoder /rerank endpoint.
+type Reranker interface {
+	Rerank(ctx context.Context, query string, texts []string) ([]int, error)
+}
+
+// Generator produces a completion for a prompt, with a streaming variant.
+// VLLMService is the default implementation; OllamaGenerator is a drop-in
+// alternative selected via the backends manifest.
+type Generator interface {
+	GenerateResponse(ctx context.Context, prompt string) (string, error)
+	GenerateResponseStream(ctx context.Context, prompt string) (<-chan Token, error)
+}
+
+var (
+	_ Embedder    = (*TEIService)(nil)
+	_ Embedder    = (*OpenAIEmbedder)(nil)
+	_ VectorStore = (*WeaviateService)(nil)
+	_ VectorStore = (*QdrantVectorStore)(nil)
+	_ Generator   = (*VLLMService)(nil)
+	_ Generator   = (*OllamaGenerator)(nil)
+	_ Reranker    = (*TEIService)(nil)
+)
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// inflightLimiter bounds how many concurrent calls a backend client issues,
+// so a slow downstream service can't let unbounded goroutines pile up behind
+// it. Acquiring records queue depth and wait time against the backend's
+// /metrics series, so saturation shows up there instead of as a silent
+// latency spike.
+type inflightLimiter struct {
+	sem     *semaphore.Weighted
+	backend string
+}
+
+func newInflightLimiter(backend string, maxInflight int) *inflightLimiter {
+	if maxInflight <= 0 {
+		maxInflight = 1
+	}
+	return &inflightLimiter{
+		sem:     semaphore.NewWeighted(int64(maxInflight)),
+		backend: backend,
+	}
+}
+
+// acquire blocks until a slot is free or ctx is done. On success it returns
+// a release func the caller must defer; on failure (ctx canceled while
+// queued) it returns ctx's error.
+func (l *inflightLimiter) acquire(ctx context.Context) (func(), error) {
+	observeQueueDepth(l.backend, 1)
+	start := time.Now()
+	defer func() {
+		observeQueueDepth(l.backend, -1)
+		observeQueueWait(l.backend, time.Since(start))
+	}()
+
+	if err := l.sem.Acquire(ctx, 1); err != nil {
+		return func() {}, err
+	}
+
+	return func() { l.sem.Release(1) }, nil
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics exposed at /metrics: per-backend concurrency-limiter queue depth
+// and wait time (TEI_MAX_INFLIGHT / VLLM_MAX_INFLIGHT saturation), and a
+// count of RAGService pipeline stages that exceeded their soft deadline.
+var (
+	backendQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "rag_api",
+		Name:      "backend_queue_depth",
+		Help:      "Calls currently waiting for a backend concurrency slot.",
+	}, []string{"backend"})
+
+	backendQueueWaitSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "rag_api",
+		Name:      "backend_queue_wait_seconds",
+		Help:      "Time a call waited for a backend concurrency slot before running.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"backend"})
+
+	stageTimeoutsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rag_api",
+		Name:      "stage_timeouts_total",
+		Help:      "RAGService pipeline stages that exceeded their configured soft deadline.",
+	}, []string{"stage"})
+)
+
+func init() {
+	prometheus.MustRegister(backendQueueDepth, backendQueueWaitSeconds, stageTimeoutsTotal)
+}
+
+func observeQueueDepth(backend string, delta float64) {
+	backendQueueDepth.WithLabelValues(backend).Add(delta)
+}
+
+func observeQueueWait(backend string, d time.Duration) {
+	backendQueueWaitSeconds.WithLabelValues(backend).Observe(d.Seconds())
+}
+
+func recordStageTimeout(stage Stage) {
+	stageTimeoutsTotal.WithLabelValues(string(stage)).Inc()
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// OllamaGenerator is a Generator backed by Ollama's /api/generate endpoint,
+// selectable in place of VLLMService via the backends manifest. llama.cpp's
+// server speaks the OpenAI protocol directly, so it reuses VLLMService
+// instead of needing its own implementation here.
+type OllamaGenerator struct {
+	baseURL    string
+	model      string
+	maxTokens  int
+	httpClient *http.Client
+}
+
+func NewOllamaGenerator(baseURL, model string, maxTokens int, httpClient *http.Client) *OllamaGenerator {
+	return &OllamaGenerator{
+		baseURL:    baseURL,
+		model:      model,
+		maxTokens:  maxTokens,
+		httpClient: httpClient,
+	}
+}
+
+type ollamaGenerateRequest struct {
+	Model   string `json:"model"`
+	Prompt  string `json:"prompt"`
+	Stream  bool   `json:"stream"`
+	Options struct {
+		NumPredict int `json:"num_predict,omitempty"`
+	} `json:"options,omitempty"`
+}
+
+type ollamaGenerateResponse struct {
+	Response string `json:"response"`
+	Done     bool   `json:"done"`
+}
+
+func (g *OllamaGenerator) GenerateResponse(ctx context.Context, prompt string) (string, error) {
+	payload := ollamaGenerateRequest{Model: g.model, Prompt: prompt}
+	payload.Options.NumPredict = g.maxTokens
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal Ollama request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.baseURL+"/api/generate", bytes.NewBuffer(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create Ollama request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Ollama request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Ollama server returned status %d", resp.StatusCode)
+	}
+
+	var result ollamaGenerateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode Ollama response: %w", err)
+	}
+
+	return result.Response, nil
+}
+
+// GenerateResponseStream streams Ollama's newline-delimited JSON response
+// chunks as Tokens.
+func (g *OllamaGenerator) GenerateResponseStream(ctx context.Context, prompt string) (<-chan Token, error) {
+	payload := ollamaGenerateRequest{Model: g.model, Prompt: prompt, Stream: true}
+	payload.Options.NumPredict = g.maxTokens
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Ollama request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.baseURL+"/api/generate", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Ollama request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Ollama request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("Ollama server returned status %d", resp.StatusCode)
+	}
+
+	tokens := make(chan Token)
+	go func() {
+		defer close(tokens)
+		defer resp.Body.Close()
+
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var chunk ollamaGenerateResponse
+			if err := decoder.Decode(&chunk); err != nil {
+				if !errors.Is(err, io.EOF) {
+					select {
+					case tokens <- Token{Err: fmt.Errorf("Ollama stream failed: %w", err)}:
+					case <-ctx.Done():
+					}
+				}
+				return
+			}
+
+			select {
+			case tokens <- Token{Content: chunk.Response}:
+			case <-ctx.Done():
+				return
+			}
+
+			if chunk.Done {
+				return
+			}
+		}
+	}()
+
+	return tokens, nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// OpenAIEmbedder is an Embedder backed by the OpenAI (or any
+// OpenAI-compatible) embeddings endpoint, selectable in place of TEIService
+// via the backends manifest.
+type OpenAIEmbedder struct {
+	client *openai.Client
+	model  string
+}
+
+func NewOpenAIEmbedder(baseURL, apiKey, model string) *OpenAIEmbedder {
+	cfg := openai.DefaultConfig(apiKey)
+	if baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+
+	return &OpenAIEmbedder{
+		client: openai.NewClientWithConfig(cfg),
+		model:  model,
+	}
+}
+
+func (e *OpenAIEmbedder) GetEmbedding(ctx context.Context, text string) ([]float64, error) {
+	embeddings, err := e.GetEmbeddings(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+
+	return embeddings[0], nil
+}
+
+func (e *OpenAIEmbedder) GetEmbeddings(ctx context.Context, texts []string) ([][]float64, error) {
+	resp, err := e.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+		Input: texts,
+		Model: openai.EmbeddingModel(e.model),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI embeddings request failed: %w", err)
+	}
+
+	embeddings := make([][]float64, len(resp.Data))
+	for i, d := range resp.Data {
+		embeddings[i] = toFloat64(d.Embedding)
+	}
+
+	return embeddings, nil
+}
+
+func (e *OpenAIEmbedder) GetEmbeddingsBatch(ctx context.Context, texts []string, batchSize int) ([][]float64, error) {
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
+	embeddings := make([][]float64, 0, len(texts))
+	for start := 0; start < len(texts); start += batchSize {
+		end := start + batchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		batch, err := e.GetEmbeddings(ctx, texts[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed batch %d-%d: %w", start, end, err)
+		}
+
+		embeddings = append(embeddings, batch...)
+	}
+
+	return embeddings, nil
+}
+
+func toFloat64(v []float32) []float64 {
+	out := make([]float64, len(v))
+	for i, f := range v {
+		out[i] = float64(f)
+	}
+	return out
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// QdrantVectorStore is a VectorStore backed by Qdrant's REST API,
+// selectable in place of WeaviateService via the backends manifest.
+type QdrantVectorStore struct {
+	baseURL        string
+	collectionName string
+	searchLimit    int
+	httpClient     *http.Client
+}
+
+func NewQdrantVectorStore(baseURL, collectionName string, searchLimit int, httpClient *http.Client) *QdrantVectorStore {
+	return &QdrantVectorStore{
+		baseURL:        baseURL,
+		collectionName: collectionName,
+		searchLimit:    searchLimit,
+		httpClient:     httpClient,
+	}
+}
+
+type qdrantSearchRequest struct {
+	Vector      []float32 `json:"vector"`
+	Limit       int       `json:"limit"`
+	WithPayload bool      `json:"with_payload"`
+}
+
+type qdrantSearchResponse struct {
+	Result []struct {
+		Payload map[string]interface{} `json:"payload"`
+	} `json:"result"`
+}
+
+// Retrieve falls back to dense-only search: Qdrant's payload index doesn't
+// give us a BM25 leg to fuse here, so query and opts are accepted for
+// interface compatibility but otherwise unused.
+func (s *QdrantVectorStore) Retrieve(ctx context.Context, query string, embedding []float64, opts RetrieveOptions) ([]string, error) {
+	return s.SearchSimilar(ctx, embedding)
+}
+
+func (s *QdrantVectorStore) SearchSimilar(ctx context.Context, embedding []float64) ([]string, error) {
+	payload := qdrantSearchRequest{
+		Vector:      toVector32(embedding),
+		Limit:       s.searchLimit,
+		WithPayload: true,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Qdrant search request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/collections/%s/points/search", s.baseURL, s.collectionName), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Qdrant request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Qdrant search request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Qdrant server returned status %d", resp.StatusCode)
+	}
+
+	var result qdrantSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode Qdrant response: %w", err)
+	}
+
+	chunks := make([]string, 0, len(result.Result))
+	for _, point := range result.Result {
+		if text, ok := point.Payload["text"].(string); ok && text != "" {
+			chunks = append(chunks, text)
+		}
+	}
+
+	return chunks, nil
+}
+
+type qdrantPoint struct {
+	ID      uint64                 `json:"id"`
+	Vector  []float32              `json:"vector"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+type qdrantUpsertRequest struct {
+	Points []qdrantPoint `json:"points"`
+}
+
+func (s *QdrantVectorStore) IndexChunks(ctx context.Context, chunks []Chunk) error {
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	points := make([]qdrantPoint, len(chunks))
+	for i, chunk := range chunks {
+		points[i] = qdrantPoint{
+			ID:     qdrantPointID(chunk),
+			Vector: toVector32(chunk.Embedding),
+			Payload: map[string]interface{}{
+				"text":       chunk.Text,
+				"source":     chunk.Source,
+				"chunkIndex": chunk.Index,
+				"hash":       chunk.Hash,
+			},
+		}
+	}
+
+	body, err := json.Marshal(qdrantUpsertRequest{Points: points})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Qdrant upsert request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut,
+		fmt.Sprintf("%s/collections/%s/points", s.baseURL, s.collectionName), bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Qdrant request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Qdrant upsert request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Qdrant server returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// qdrantPointID derives a Qdrant-compatible unsigned point ID (Qdrant only
+// accepts UUIDs or uint64s) from a chunk's occurrence, not just its content
+// hash: identical text recurring across documents (a shared disclaimer,
+// header/footer, boilerplate sentence) hashes the same way, and an ID keyed
+// on hash alone would make a later ingest's upsert silently overwrite an
+// earlier chunk's point instead of adding a new one. Keying on
+// (Source, Index, Hash) keeps every occurrence distinct while still being
+// deterministic, so re-ingesting the same document updates its own points
+// in place rather than duplicating them.
+func qdrantPointID(chunk Chunk) uint64 {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%s", chunk.Source, chunk.Index, chunk.Hash)))
+	key := hex.EncodeToString(sum[:])
+
+	id, err := strconv.ParseUint(key[:16], 16, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
 const promptTemplate = `<|begin_of_text|><|start_header_id|>system<|end_header_id|>
@@ -18,39 +20,222 @@ Question:
 `
 
 type RAGService struct {
-	tei      *TEIService
-	weaviate *WeaviateService
-	vllm     *VLLMService
+	embedder      Embedder
+	vectorStore   VectorStore
+	generator     Generator
+	reranker      Reranker
+	defaultOpts   RetrieveOptions
+	stageTimeouts StageTimeouts
 }
 
-func NewRAGService(tei *TEIService, weaviate *WeaviateService, vllm *VLLMService) *RAGService {
+func NewRAGService(embedder Embedder, vectorStore VectorStore, generator Generator, reranker Reranker, defaultOpts RetrieveOptions, stageTimeouts StageTimeouts) *RAGService {
 	return &RAGService{
-		tei:      tei,
-		weaviate: weaviate,
-		vllm:     vllm,
+		embedder:      embedder,
+		vectorStore:   vectorStore,
+		generator:     generator,
+		reranker:      reranker,
+		defaultOpts:   defaultOpts,
+		stageTimeouts: stageTimeouts,
 	}
 }
 
-func (s *RAGService) Query(ctx context.Context, query string) (string, error) {
-	embedding, err := s.tei.GetEmbedding(ctx, query)
+func (s *RAGService) Query(ctx context.Context, query string, opts RetrieveOptions) (string, error) {
+	contextChunks, err := s.retrieveChunks(ctx, query, opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to get embedding: %w", err)
+		return "", err
 	}
 
-	contextChunks, err := s.weaviate.SearchSimilar(ctx, embedding)
+	contextStr := buildContext(contextChunks)
+	finalPrompt := fmt.Sprintf(promptTemplate, contextStr, query)
+
+	var answer string
+	err = withStageDeadline(ctx, StageGeneration, s.stageTimeouts.Generation, func(stageCtx context.Context) error {
+		var genErr error
+		answer, genErr = s.generator.GenerateResponse(stageCtx, finalPrompt)
+		return genErr
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to search Weaviate: %w", err)
+		var timeoutErr *StageTimeoutError
+		if errors.As(err, &timeoutErr) {
+			return "", timeoutErr
+		}
+		return "", fmt.Errorf("failed to generate answer: %w", err)
+	}
+
+	return answer, nil
+}
+
+// RetrieveContext runs the embedding + retrieval stages of Query and returns
+// the formatted context string without generating an answer, for callers
+// (such as the OpenAI-compatible chat endpoint) that assemble their own
+// prompt around it.
+func (s *RAGService) RetrieveContext(ctx context.Context, query string) (string, error) {
+	contextChunks, err := s.retrieveChunks(ctx, query, RetrieveOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return buildContext(contextChunks), nil
+}
+
+// StreamEventType identifies what a StreamEvent carries.
+type StreamEventType string
+
+const (
+	StreamEventSources StreamEventType = "sources"
+	StreamEventToken   StreamEventType = "token"
+)
+
+// StreamEvent is one message of a QueryStream: a single "sources" event
+// carrying the retrieved chunks, followed by a "token" event per generated
+// token delta. Err is set on the final event if generation failed mid-stream.
+type StreamEvent struct {
+	Type    StreamEventType
+	Sources []string
+	Token   string
+	Err     error
+}
+
+// QueryStream is the streaming counterpart to Query: it performs the same
+// embedding and retrieval stages, then streams the generation stage instead
+// of waiting for the full answer, emitting the retrieved chunks first so a
+// caller can display sources before the first token arrives.
+func (s *RAGService) QueryStream(ctx context.Context, query string, opts RetrieveOptions) (<-chan StreamEvent, error) {
+	contextChunks, err := s.retrieveChunks(ctx, query, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	contextStr := buildContext(contextChunks)
 	finalPrompt := fmt.Sprintf(promptTemplate, contextStr, query)
 
-	answer, err := s.vllm.GenerateResponse(ctx, finalPrompt)
+	// The generation stage's soft deadline has to span the whole stream, not
+	// just its start, so unlike the other stages we can't use
+	// withStageDeadline here: canceling genCtx on return (as its deferred
+	// cancel would) would kill the stream the instant it started. cancel is
+	// instead deferred inside the forwarding goroutine below, once the
+	// stream actually ends.
+	genCtx := ctx
+	var cancel context.CancelFunc
+	if s.stageTimeouts.Generation > 0 {
+		genCtx, cancel = context.WithTimeout(ctx, s.stageTimeouts.Generation)
+	}
+
+	tokens, err := s.generator.GenerateResponseStream(genCtx, finalPrompt)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate answer: %w", err)
+		if cancel != nil {
+			cancel()
+		}
+		return nil, fmt.Errorf("failed to start generation stream: %w", err)
 	}
 
-	return answer, nil
+	events := make(chan StreamEvent, 1)
+	go func() {
+		defer close(events)
+		if cancel != nil {
+			defer cancel()
+		}
+
+		events <- StreamEvent{Type: StreamEventSources, Sources: contextChunks}
+
+		for token := range tokens {
+			if token.Err != nil {
+				events <- StreamEvent{Err: generationStageErr(genCtx, s.stageTimeouts.Generation, token.Err)}
+				return
+			}
+			events <- StreamEvent{Type: StreamEventToken, Token: token.Content}
+		}
+
+		if genCtx.Err() == context.DeadlineExceeded {
+			recordStageTimeout(StageGeneration)
+			events <- StreamEvent{Err: &StageTimeoutError{Stage: StageGeneration, Timeout: s.stageTimeouts.Generation}}
+		}
+	}()
+
+	return events, nil
+}
+
+// generationStageErr translates err into a StageTimeoutError if genCtx's
+// deadline is why the generation stream failed.
+func generationStageErr(genCtx context.Context, timeout time.Duration, err error) error {
+	if genCtx.Err() == context.DeadlineExceeded {
+		recordStageTimeout(StageGeneration)
+		return &StageTimeoutError{Stage: StageGeneration, Timeout: timeout}
+	}
+	return err
+}
+
+func (s *RAGService) retrieveChunks(ctx context.Context, query string, opts RetrieveOptions) ([]string, error) {
+	var embedding []float64
+	err := withStageDeadline(ctx, StageEmbedding, s.stageTimeouts.Embedding, func(stageCtx context.Context) error {
+		var embErr error
+		embedding, embErr = s.embedder.GetEmbedding(stageCtx, query)
+		return embErr
+	})
+	if err != nil {
+		var timeoutErr *StageTimeoutError
+		if errors.As(err, &timeoutErr) {
+			return nil, timeoutErr
+		}
+		return nil, fmt.Errorf("failed to get embedding: %w", err)
+	}
+
+	opts = s.resolveOptions(opts)
+
+	var candidates []string
+	err = withStageDeadline(ctx, StageRetrieval, s.stageTimeouts.Retrieval, func(stageCtx context.Context) error {
+		var retErr error
+		candidates, retErr = s.vectorStore.Retrieve(stageCtx, query, embedding, opts)
+		if retErr != nil {
+			return retErr
+		}
+
+		if opts.RerankTopN > 0 && s.reranker != nil && len(candidates) > 1 {
+			rerankCandidates := candidates
+			if opts.RerankTopN < len(rerankCandidates) {
+				rerankCandidates = rerankCandidates[:opts.RerankTopN]
+			}
+
+			order, rerankErr := s.reranker.Rerank(stageCtx, query, rerankCandidates)
+			if rerankErr != nil {
+				return rerankErr
+			}
+			candidates = reorder(rerankCandidates, order)
+		}
+		return nil
+	})
+	if err != nil {
+		var timeoutErr *StageTimeoutError
+		if errors.As(err, &timeoutErr) {
+			return nil, timeoutErr
+		}
+		return nil, fmt.Errorf("failed to retrieve from vector store: %w", err)
+	}
+
+	if opts.FinalK > 0 && opts.FinalK < len(candidates) {
+		candidates = candidates[:opts.FinalK]
+	}
+
+	return candidates, nil
+}
+
+// resolveOptions fills any zero-valued field of opts (a request-supplied
+// override) from the service's configured defaults.
+func (s *RAGService) resolveOptions(opts RetrieveOptions) RetrieveOptions {
+	resolved := s.defaultOpts
+	if opts.Alpha != 0 {
+		resolved.Alpha = opts.Alpha
+	}
+	if opts.K != 0 {
+		resolved.K = opts.K
+	}
+	if opts.RerankTopN != 0 {
+		resolved.RerankTopN = opts.RerankTopN
+	}
+	if opts.FinalK != 0 {
+		resolved.FinalK = opts.FinalK
+	}
+	return resolved
 }
 
 func buildContext(chunks []string) string {
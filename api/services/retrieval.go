@@ -0,0 +1,82 @@
+package services
+
+import "sort"
+
+// RetrieveOptions tunes hybrid retrieval: blending dense (vector) and sparse
+// (BM25) search via Reciprocal Rank Fusion, with an optional cross-encoder
+// rerank pass over the fused candidates.
+type RetrieveOptions struct {
+	// Alpha weights each leg's RRF contribution: the vector leg gets Alpha,
+	// the BM25 leg gets 1-Alpha. 1 is vector-only, 0 is BM25-only.
+	Alpha float64
+	// K is the RRF smoothing constant (score += 1/(K+rank)); larger values
+	// flatten the influence of rank position.
+	K int
+	// RerankTopN is how many fused candidates to send through the
+	// cross-encoder reranker. 0 disables reranking.
+	RerankTopN int
+	// FinalK is how many chunks retrieval ultimately returns.
+	FinalK int
+}
+
+// hit is one retrieved candidate before or after fusion: its vector store id
+// (used to dedupe the same chunk surfaced by both search legs) and its text.
+type hit struct {
+	id   string
+	text string
+}
+
+// fuseRRF combines two rank-ordered result lists into one via Reciprocal Rank
+// Fusion: score = alpha/(k+rank_vector) + (1-alpha)/(k+rank_bm25), with a leg
+// contributing 0 for any id it didn't surface.
+func fuseRRF(vectorHits, bm25Hits []hit, alpha float64, k int) []hit {
+	type scored struct {
+		hit   hit
+		score float64
+	}
+
+	byID := make(map[string]*scored)
+	order := make([]string, 0, len(vectorHits)+len(bm25Hits))
+
+	add := func(h hit, score float64) {
+		s, ok := byID[h.id]
+		if !ok {
+			s = &scored{hit: h}
+			byID[h.id] = s
+			order = append(order, h.id)
+		}
+		s.score += score
+	}
+
+	for rank, h := range vectorHits {
+		add(h, alpha*(1.0/float64(k+rank+1)))
+	}
+	for rank, h := range bm25Hits {
+		add(h, (1-alpha)*(1.0/float64(k+rank+1)))
+	}
+
+	fused := make([]scored, 0, len(order))
+	for _, id := range order {
+		fused = append(fused, *byID[id])
+	}
+
+	sort.SliceStable(fused, func(i, j int) bool { return fused[i].score > fused[j].score })
+
+	results := make([]hit, len(fused))
+	for i, s := range fused {
+		results[i] = s.hit
+	}
+	return results
+}
+
+// reorder rearranges items according to order, a slice of indices into items
+// (as returned by a Reranker), dropping any out-of-range index.
+func reorder(items []string, order []int) []string {
+	reordered := make([]string, 0, len(order))
+	for _, idx := range order {
+		if idx >= 0 && idx < len(items) {
+			reordered = append(reordered, items[idx])
+		}
+	}
+	return reordered
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 
 	"rag-api/models"
 )
@@ -14,18 +15,45 @@ import (
 type TEIService struct {
 	baseURL    string
 	httpClient *http.Client
+	limiter    *inflightLimiter
 }
 
-func NewTEIService(baseURL string, httpClient *http.Client) *TEIService {
+func NewTEIService(baseURL string, httpClient *http.Client, maxInflight int) *TEIService {
 	return &TEIService{
 		baseURL:    baseURL,
 		httpClient: httpClient,
+		limiter:    newInflightLimiter("tei", maxInflight),
 	}
 }
 
 func (s *TEIService) GetEmbedding(ctx context.Context, text string) ([]float64, error) {
+	embeddings, err := s.GetEmbeddings(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(embeddings) == 0 || len(embeddings[0]) == 0 {
+		return nil, errors.New("empty embedding response from TEI")
+	}
+
+	return embeddings[0], nil
+}
+
+// GetEmbeddings embeds a batch of texts in a single TEI request, preserving
+// input order in the returned slice.
+func (s *TEIService) GetEmbeddings(ctx context.Context, texts []string) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, errors.New("texts cannot be empty")
+	}
+
+	release, err := s.limiter.acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TEI request canceled while waiting for a concurrency slot: %w", err)
+	}
+	defer release()
+
 	payload := models.TEIRequest{
-		Inputs:    []string{text},
+		Inputs:    texts,
 		Truncate:  true,
 		Normalize: true,
 	}
@@ -57,9 +85,97 @@ func (s *TEIService) GetEmbedding(ctx context.Context, text string) ([]float64,
 		return nil, fmt.Errorf("failed to decode TEI response: %w", err)
 	}
 
-	if len(response) == 0 || len(response[0]) == 0 {
-		return nil, errors.New("empty embedding response from TEI")
+	if len(response) != len(texts) {
+		return nil, fmt.Errorf("TEI returned %d embeddings for %d inputs", len(response), len(texts))
 	}
 
-	return response[0], nil
+	return response, nil
+}
+
+// GetEmbeddingsBatch embeds a large set of texts by splitting them into
+// batches of at most batchSize, so ingestion of a large document doesn't
+// send a single oversized request to TEI.
+func (s *TEIService) GetEmbeddingsBatch(ctx context.Context, texts []string, batchSize int) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, errors.New("texts cannot be empty")
+	}
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
+	embeddings := make([][]float64, 0, len(texts))
+	for start := 0; start < len(texts); start += batchSize {
+		end := start + batchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		batch, err := s.GetEmbeddings(ctx, texts[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed batch %d-%d: %w", start, end, err)
+		}
+
+		embeddings = append(embeddings, batch...)
+	}
+
+	return embeddings, nil
+}
+
+type teiRerankRequest struct {
+	Query string   `json:"query"`
+	Texts []string `json:"texts"`
+}
+
+type teiRerankResult struct {
+	Index int     `json:"index"`
+	Score float64 `json:"score"`
+}
+
+// Rerank scores texts against query via TEI's cross-encoder /rerank
+// endpoint, returning indices into texts ordered best-first.
+func (s *TEIService) Rerank(ctx context.Context, query string, texts []string) ([]int, error) {
+	if len(texts) == 0 {
+		return nil, errors.New("texts cannot be empty")
+	}
+
+	release, err := s.limiter.acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TEI request canceled while waiting for a concurrency slot: %w", err)
+	}
+	defer release()
+
+	payloadBytes, err := json.Marshal(teiRerankRequest{Query: query, Texts: texts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal TEI rerank request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		s.baseURL+"/rerank", bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TEI rerank request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("TEI rerank request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("TEI server returned status %d", resp.StatusCode)
+	}
+
+	var results []teiRerankResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode TEI rerank response: %w", err)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+
+	order := make([]int, len(results))
+	for i, r := range results {
+		order[i] = r.Index
+	}
+	return order, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/sashabaranov/go-openai"
 )
@@ -12,13 +13,15 @@ type VLLMService struct {
 	client    *openai.Client
 	modelName string
 	maxTokens int
+	limiter   *inflightLimiter
 }
 
-func NewVLLMService(client *openai.Client, modelName string, maxTokens int) *VLLMService {
+func NewVLLMService(client *openai.Client, modelName string, maxTokens int, maxInflight int) *VLLMService {
 	return &VLLMService{
 		client:    client,
 		modelName: modelName,
 		maxTokens: maxTokens,
+		limiter:   newInflightLimiter("vllm", maxInflight),
 	}
 }
 
@@ -35,6 +38,12 @@ func (s *VLLMService) GenerateResponse(ctx context.Context, prompt string) (stri
 		Stop:      []string{"<|eot_id|>"},
 	}
 
+	release, err := s.limiter.acquire(ctx)
+	if err != nil {
+		return "", fmt.Errorf("vLLM request canceled while waiting for a concurrency slot: %w", err)
+	}
+	defer release()
+
 	resp, err := s.client.CreateChatCompletion(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("vLLM completion failed: %w", err)
@@ -46,3 +55,137 @@ func (s *VLLMService) GenerateResponse(ctx context.Context, prompt string) (stri
 
 	return resp.Choices[0].Message.Content, nil
 }
+
+// CreateChatCompletion forwards an OpenAI-style chat request to vLLM as-is,
+// defaulting Model and MaxTokens when the caller left them unset.
+func (s *VLLMService) CreateChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	if req.Model == "" {
+		req.Model = s.modelName
+	}
+	if req.MaxTokens == 0 {
+		req.MaxTokens = s.maxTokens
+	}
+
+	release, err := s.limiter.acquire(ctx)
+	if err != nil {
+		return openai.ChatCompletionResponse{}, fmt.Errorf("vLLM request canceled while waiting for a concurrency slot: %w", err)
+	}
+	defer release()
+
+	resp, err := s.client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return openai.ChatCompletionResponse{}, fmt.Errorf("vLLM chat completion failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// CreateCompletion forwards an OpenAI-style legacy completion request to vLLM.
+func (s *VLLMService) CreateCompletion(ctx context.Context, req openai.CompletionRequest) (openai.CompletionResponse, error) {
+	if req.Model == "" {
+		req.Model = s.modelName
+	}
+	if req.MaxTokens == 0 {
+		req.MaxTokens = s.maxTokens
+	}
+
+	release, err := s.limiter.acquire(ctx)
+	if err != nil {
+		return openai.CompletionResponse{}, fmt.Errorf("vLLM request canceled while waiting for a concurrency slot: %w", err)
+	}
+	defer release()
+
+	resp, err := s.client.CreateCompletion(ctx, req)
+	if err != nil {
+		return openai.CompletionResponse{}, fmt.Errorf("vLLM completion failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Token is one chunk of a streamed completion. Err is set on the final value
+// sent before the channel closes if the stream ended abnormally.
+type Token struct {
+	Content string
+	Err     error
+}
+
+// GenerateResponseStream is the streaming counterpart to GenerateResponse: it
+// wraps prompt in the same chat shape but streams token deltas as they
+// arrive instead of waiting for the full completion.
+func (s *VLLMService) GenerateResponseStream(ctx context.Context, prompt string) (<-chan Token, error) {
+	req := openai.ChatCompletionRequest{
+		Model: s.modelName,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: prompt,
+			},
+		},
+		MaxTokens: s.maxTokens,
+		Stop:      []string{"<|eot_id|>"},
+	}
+
+	return s.streamChatCompletion(ctx, req)
+}
+
+// StreamChatCompletion is the streaming counterpart to CreateChatCompletion,
+// for callers forwarding an already-built chat request.
+func (s *VLLMService) StreamChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (<-chan Token, error) {
+	if req.Model == "" {
+		req.Model = s.modelName
+	}
+	if req.MaxTokens == 0 {
+		req.MaxTokens = s.maxTokens
+	}
+
+	return s.streamChatCompletion(ctx, req)
+}
+
+func (s *VLLMService) streamChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (<-chan Token, error) {
+	req.Stream = true
+
+	release, err := s.limiter.acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("vLLM request canceled while waiting for a concurrency slot: %w", err)
+	}
+
+	stream, err := s.client.CreateChatCompletionStream(ctx, req)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to start vLLM stream: %w", err)
+	}
+
+	tokens := make(chan Token)
+	go func() {
+		defer close(tokens)
+		defer stream.Close()
+		defer release()
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				select {
+				case tokens <- Token{Err: fmt.Errorf("vLLM stream failed: %w", err)}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			if len(resp.Choices) == 0 {
+				continue
+			}
+
+			select {
+			case tokens <- Token{Content: resp.Choices[0].Delta.Content}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return tokens, nil
+}
@@ -24,32 +24,87 @@ func NewWeaviateService(client *weaviate.Client, collectionName string, searchLi
 	}
 }
 
-func (s *WeaviateService) SearchSimilar(ctx context.Context, embedding []float64) ([]string, error) {
-	vector32 := make([]float32, len(embedding))
-	for i, v := range embedding {
-		vector32[i] = float32(v)
+// Retrieve runs a hybrid search: a dense nearVector search and a sparse BM25
+// keyword search, fused by Reciprocal Rank Fusion over the union of matched
+// object ids. The cross-encoder rerank stage lives in RAGService instead of
+// here, since it talks to a different backend (TEI) than the vector store.
+func (s *WeaviateService) Retrieve(ctx context.Context, query string, embedding []float64, opts RetrieveOptions) ([]string, error) {
+	fetchLimit := opts.FinalK
+	if opts.RerankTopN > fetchLimit {
+		fetchLimit = opts.RerankTopN
+	}
+	if fetchLimit <= 0 {
+		fetchLimit = s.searchLimit
+	}
+
+	vectorHits, err := s.nearVectorSearch(ctx, embedding, fetchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("Weaviate nearVector search failed: %w", err)
 	}
 
-	fields := []graphql.Field{{Name: "text"}}
+	bm25Hits, err := s.bm25Search(ctx, query, fetchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("Weaviate bm25 search failed: %w", err)
+	}
+
+	fused := fuseRRF(vectorHits, bm25Hits, opts.Alpha, opts.K)
+	if len(fused) > fetchLimit {
+		fused = fused[:fetchLimit]
+	}
+
+	texts := make([]string, len(fused))
+	for i, h := range fused {
+		texts[i] = h.text
+	}
+	return texts, nil
+}
+
+func (s *WeaviateService) nearVectorSearch(ctx context.Context, embedding []float64, limit int) ([]hit, error) {
 	nearVector := s.client.GraphQL().
 		NearVectorArgBuilder().
-		WithVector(vector32)
+		WithVector(toVector32(embedding))
 
 	resp, err := s.client.GraphQL().
 		Get().
 		WithClassName(s.collectionName).
-		WithFields(fields...).
+		WithFields(hitFields()...).
 		WithNearVector(nearVector).
-		WithLimit(s.searchLimit).
+		WithLimit(limit).
 		Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Weaviate query failed: %w", err)
+		return nil, err
 	}
 
-	return extractTextChunks(resp.Data, s.collectionName)
+	return extractHits(resp.Data, s.collectionName)
+}
+
+func (s *WeaviateService) bm25Search(ctx context.Context, query string, limit int) ([]hit, error) {
+	bm25 := s.client.GraphQL().
+		Bm25ArgBuilder().
+		WithQuery(query)
+
+	resp, err := s.client.GraphQL().
+		Get().
+		WithClassName(s.collectionName).
+		WithFields(hitFields()...).
+		WithBM25(bm25).
+		WithLimit(limit).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return extractHits(resp.Data, s.collectionName)
+}
+
+func hitFields() []graphql.Field {
+	return []graphql.Field{
+		{Name: "text"},
+		{Name: "_additional", Fields: []graphql.Field{{Name: "id"}}},
+	}
 }
 
-func extractTextChunks(data map[string]models.JSONObject, collectionName string) ([]string, error) {
+func extractHits(data map[string]models.JSONObject, collectionName string) ([]hit, error) {
 	get, ok := data["Get"].(map[string]interface{})
 	if !ok {
 		return nil, errors.New("invalid GraphQL response: missing Get field")
@@ -60,17 +115,82 @@ func extractTextChunks(data map[string]models.JSONObject, collectionName string)
 		return nil, fmt.Errorf("invalid GraphQL response: missing collection %s", collectionName)
 	}
 
-	var chunks []string
+	var hits []hit
 	for _, item := range class {
 		itemMap, ok := item.(map[string]interface{})
 		if !ok {
 			continue
 		}
 		text, ok := itemMap["text"].(string)
-		if ok && text != "" {
-			chunks = append(chunks, text)
+		if !ok || text == "" {
+			continue
+		}
+
+		id := text
+		if additional, ok := itemMap["_additional"].(map[string]interface{}); ok {
+			if objID, ok := additional["id"].(string); ok && objID != "" {
+				id = objID
+			}
 		}
+
+		hits = append(hits, hit{id: id, text: text})
+	}
+
+	return hits, nil
+}
+
+// Chunk is a single piece of an ingested document, ready to be written to
+// Weaviate once it has been embedded.
+type Chunk struct {
+	Text      string
+	Source    string
+	Index     int
+	Hash      string
+	Embedding []float64
+}
+
+// IndexChunks writes a batch of embedded chunks to the collection in a
+// single Weaviate batch request.
+func (s *WeaviateService) IndexChunks(ctx context.Context, chunks []Chunk) error {
+	if len(chunks) == 0 {
+		return nil
 	}
 
-	return chunks, nil
+	batcher := s.client.Batch().ObjectsBatcher()
+	for _, chunk := range chunks {
+		batcher = batcher.WithObjects(&models.Object{
+			Class: s.collectionName,
+			Properties: map[string]interface{}{
+				"text":       chunk.Text,
+				"source":     chunk.Source,
+				"chunkIndex": chunk.Index,
+				"hash":       chunk.Hash,
+			},
+			Vector: toVector32(chunk.Embedding),
+		})
+	}
+
+	resp, err := batcher.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("Weaviate batch index failed: %w", err)
+	}
+
+	for _, result := range resp {
+		if result.Result == nil || result.Result.Errors == nil {
+			continue
+		}
+		for _, objErr := range result.Result.Errors.Error {
+			return fmt.Errorf("Weaviate object indexing error: %s", objErr.Message)
+		}
+	}
+
+	return nil
+}
+
+func toVector32(embedding []float64) []float32 {
+	vector32 := make([]float32, len(embedding))
+	for i, v := range embedding {
+		vector32[i] = float32(v)
+	}
+	return vector32
 }